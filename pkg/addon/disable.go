@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+// NameLabel marks the Application objects created by `vela addon enable`,
+// keyed by the addon's name.
+const NameLabel = "addons.oam.dev/name"
+
+// argsSecretPrefix is the prefix `vela addon enable` uses for the Secret it
+// stores the addon's input parameters in.
+const argsSecretPrefix = "addon-secret-"
+
+// DisableAddon disables the named addon the same way the `vela addon
+// disable` command does: it deletes the addon's Application, letting the
+// core controller reclaim every resource tracked by that Application's
+// ResourceTracker, and removes the Secret the addon's input parameters were
+// stored in so a later `vela addon enable` starts clean.
+func DisableAddon(ctx context.Context, k8sClient client.Client, app v1beta1.Application) error {
+	name := app.Labels[NameLabel]
+	if name == "" {
+		return fmt.Errorf("application %s/%s is missing the %s label", app.Namespace, app.Name, NameLabel)
+	}
+
+	if err := k8sClient.Delete(ctx, &app); err != nil && !apierror.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete application for addon %s", name)
+	}
+
+	var argsSecret corev1.Secret
+	secretKey := apitypes.NamespacedName{Namespace: app.Namespace, Name: argsSecretPrefix + name}
+	if err := k8sClient.Get(ctx, secretKey, &argsSecret); err != nil {
+		if apierror.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to check argument secret for addon %s", name)
+	}
+	if err := k8sClient.Delete(ctx, &argsSecret); err != nil && !apierror.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete argument secret for addon %s", name)
+	}
+	return nil
+}
@@ -18,29 +18,90 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
 	"github.com/oam-dev/kubevela/apis/types"
+	pkgaddon "github.com/oam-dev/kubevela/pkg/addon"
 	"github.com/oam-dev/kubevela/pkg/utils/common"
 	"github.com/oam-dev/kubevela/pkg/utils/helm"
 	"github.com/oam-dev/kubevela/pkg/utils/util"
 )
 
+// pollInterval is how often we re-check whether resources have terminated
+// while waiting out --timeout.
+const pollInterval = 3 * time.Second
+
+// velaCoreDeployments are the Deployments that make up the controller and
+// must be gone before `vela uninstall --wait` returns.
+var velaCoreDeployments = []string{"kubevela-vela-core", "vela-cluster-gateway"}
+
+// addonNameLabel marks the Application objects that were created by `vela
+// addon enable`, keyed by the addon's name.
+const addonNameLabel = pkgaddon.NameLabel
+
+// resourceTrackerAppLabel links a ResourceTracker back to the Application
+// that owns it.
+const resourceTrackerAppLabel = "app.oam.dev/name"
+
+// oamCRDGroups lists the API groups owned by KubeVela whose CRDs are eligible
+// for cleanup via `vela uninstall --remove-crds`.
+var oamCRDGroups = []string{
+	"core.oam.dev",
+	"standard.oam.dev",
+	"cluster.core.oam.dev",
+}
+
 // UnInstallArgs the args for uninstall command
 type UnInstallArgs struct {
-	userInput  *UserInput
-	helmHelper *helm.Helper
-	Args       common.Args
-	Namespace  string
-	Detail     bool
+	userInput         *UserInput
+	helmHelper        *helm.Helper
+	Args              common.Args
+	Namespace         string
+	Detail            bool
+	PreserveNamespace bool
+	RemoveCRDs        bool
+	Force             bool
+	Wait              bool
+	Timeout           time.Duration
+	SkipAddons        bool
+	AddonTimeout      time.Duration
+	DryRun            bool
+	Output            string
+}
+
+// uninstallPlan is the machine-readable summary printed by `vela uninstall
+// --dry-run`. No field on it is ever derived by mutating the cluster.
+type uninstallPlan struct {
+	Release                 string         `json:"release" yaml:"release"`
+	Namespace               string         `json:"namespace" yaml:"namespace"`
+	NamespaceWillBeDeleted  bool           `json:"namespaceWillBeDeleted" yaml:"namespaceWillBeDeleted"`
+	KubevelaNamespaceExists bool           `json:"kubevelaNamespaceExists" yaml:"kubevelaNamespaceExists"`
+	Applications            int            `json:"applications" yaml:"applications"`
+	Addons                  []string       `json:"addons,omitempty" yaml:"addons,omitempty"`
+	CRDsToRemove            []string       `json:"crdsToRemove,omitempty" yaml:"crdsToRemove,omitempty"`
+	CustomResourceCounts    map[string]int `json:"customResourceCounts,omitempty" yaml:"customResourceCounts,omitempty"`
 }
 
 // NewUnInstallCommand creates `uninstall` command to uninstall vela core
@@ -53,6 +114,9 @@ func NewUnInstallCommand(c common.Args, order string, ioStreams util.IOStreams)
 		Long:    "Uninstalls KubeVela from a Kubernetes cluster.",
 		Args:    cobra.ExactArgs(0),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if unInstallArgs.DryRun {
+				return nil
+			}
 			userConfirmation := unInstallArgs.userInput.AskBool("Would you like to uninstall KubeVela from this cluster?", &UserInputOptions{AssumeYes: assumeYes})
 			if !userConfirmation {
 				return nil
@@ -68,17 +132,45 @@ func NewUnInstallCommand(c common.Args, order string, ioStreams util.IOStreams)
 			if err != nil {
 				return errors.Wrapf(err, "failed to check app in cluster")
 			}
-			if len(apps.Items) > 0 {
+			var userApps int
+			for _, app := range apps.Items {
+				// Addon-managed applications are torn down by `vela uninstall`
+				// itself via the addon-disable pipeline, so they don't block.
+				if _, isAddon := app.Labels[addonNameLabel]; !isAddon {
+					userApps++
+				}
+			}
+			if userApps > 0 {
 				return fmt.Errorf("please delete all applications before uninstall. using \"vela ls -A\" view all applications")
 			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if unInstallArgs.DryRun {
+				kubeClient, err := c.GetClient()
+				if err != nil {
+					return errors.Wrapf(err, "failed to get kube client")
+				}
+				plan, err := buildUninstallPlan(cmd.Context(), kubeClient, unInstallArgs)
+				if err != nil {
+					return err
+				}
+				return printUninstallPlan(plan, unInstallArgs.Output, ioStreams)
+			}
 			ioStreams.Info("Starting to uninstall KubeVela")
 			restConfig, err := c.GetConfig()
 			if err != nil {
 				return errors.Wrapf(err, "failed to get kube config, You can set KUBECONFIG env or make file ~/.kube/config")
 			}
+			addonClient, err := c.GetClient()
+			if err != nil {
+				return errors.Wrapf(err, "failed to get kube client")
+			}
+			if unInstallArgs.SkipAddons {
+				ioStreams.Info("Skipping addon teardown as requested by --skip-addons")
+			} else if err := teardownAddons(cmd.Context(), addonClient, unInstallArgs.AddonTimeout, ioStreams); err != nil {
+				return errors.Wrapf(err, "failed to tear down addons, re-run with --skip-addons to bypass")
+			}
 			if err := unInstallArgs.helmHelper.UninstallRelease(kubeVelaReleaseName, unInstallArgs.Namespace, restConfig, unInstallArgs.Detail, ioStreams); err != nil {
 				return err
 			}
@@ -87,28 +179,47 @@ func NewUnInstallCommand(c common.Args, order string, ioStreams util.IOStreams)
 			if err != nil {
 				return errors.Wrapf(err, "failed to get kube client")
 			}
-			if err := deleteNamespace(kubeClient, unInstallArgs.Namespace); err != nil {
-				return err
-			}
-			var namespace corev1.Namespace
-			var namespaceExists = true
-			if err := kubeClient.Get(cmd.Context(), apitypes.NamespacedName{Name: "kubevela"}, &namespace); err != nil {
-				if !apierror.IsNotFound(err) {
-					return fmt.Errorf("failed to check if namespace kubevela already exists: %w", err)
+			if unInstallArgs.PreserveNamespace {
+				ioStreams.Info(fmt.Sprintf("Preserving namespace %s as requested by --preserve-namespace", unInstallArgs.Namespace))
+			} else {
+				if err := deleteNamespace(kubeClient, unInstallArgs.Namespace); err != nil {
+					return err
+				}
+				var namespace corev1.Namespace
+				var namespaceExists = true
+				if err := kubeClient.Get(cmd.Context(), apitypes.NamespacedName{Name: "kubevela"}, &namespace); err != nil {
+					if !apierror.IsNotFound(err) {
+						return fmt.Errorf("failed to check if namespace kubevela already exists: %w", err)
+					}
+					namespaceExists = false
 				}
-				namespaceExists = false
-			}
-			if namespaceExists {
-				fmt.Printf("The namespace kubevela is exist, it is the default database of the velaux\n\n")
-				userConfirmation := unInstallArgs.userInput.AskBool("Do you want to delete it?", &UserInputOptions{assumeYes})
-				if userConfirmation {
-					if err := deleteNamespace(kubeClient, "kubevela"); err != nil {
-						return err
+				if namespaceExists {
+					fmt.Printf("The namespace kubevela is exist, it is the default database of the velaux\n\n")
+					userConfirmation := unInstallArgs.userInput.AskBool("Do you want to delete it?", &UserInputOptions{assumeYes})
+					if userConfirmation {
+						if err := deleteNamespace(kubeClient, "kubevela"); err != nil {
+							return err
+						}
 					}
 				}
 			}
+			if unInstallArgs.Wait {
+				namespaces := []string{unInstallArgs.Namespace}
+				if unInstallArgs.PreserveNamespace {
+					namespaces = nil
+				}
+				if err := waitForTermination(cmd.Context(), kubeClient, unInstallArgs.Namespace, namespaces, unInstallArgs.Timeout, ioStreams); err != nil {
+					return err
+				}
+			}
+			if unInstallArgs.RemoveCRDs {
+				if err := removeOAMCRDs(cmd.Context(), restConfig, unInstallArgs.Force, ioStreams); err != nil {
+					return err
+				}
+			} else {
+				ioStreams.Info("Please delete all CRD from cluster using \"kubectl get crd |grep oam | awk '{print $1}' | xargs kubectl delete crd\"")
+			}
 			ioStreams.Info("Successfully uninstalled KubeVela")
-			ioStreams.Info("Please delete all CRD from cluster using \"kubectl get crd |grep oam | awk '{print $1}' | xargs kubectl delete crd\"")
 			return nil
 		},
 		Annotations: map[string]string{
@@ -119,6 +230,15 @@ func NewUnInstallCommand(c common.Args, order string, ioStreams util.IOStreams)
 
 	cmd.Flags().StringVarP(&unInstallArgs.Namespace, "namespace", "n", "vela-system", "namespace scope for installing KubeVela Core")
 	cmd.Flags().BoolVarP(&unInstallArgs.Detail, "detail", "d", true, "show detail log of installation")
+	cmd.Flags().BoolVar(&unInstallArgs.PreserveNamespace, "preserve-namespace", false, "keep the namespace (and its secrets/configmaps) instead of deleting it, useful when planning to re-install")
+	cmd.Flags().BoolVar(&unInstallArgs.RemoveCRDs, "remove-crds", false, "remove all KubeVela-owned CRDs (core.oam.dev, standard.oam.dev, cluster.core.oam.dev) after the Helm release is uninstalled")
+	cmd.Flags().BoolVar(&unInstallArgs.Force, "force", false, "allow --remove-crds to proceed even if custom resources still exist for the targeted CRDs")
+	cmd.Flags().BoolVar(&unInstallArgs.Wait, "wait", false, "wait for the vela-core deployments, webhooks and namespaces to actually terminate before returning")
+	cmd.Flags().DurationVar(&unInstallArgs.Timeout, "timeout", 5*time.Minute, "how long to wait for resources to terminate when --wait is set")
+	cmd.Flags().BoolVar(&unInstallArgs.SkipAddons, "skip-addons", false, "skip tearing down installed addons before uninstalling the core controller")
+	cmd.Flags().DurationVar(&unInstallArgs.AddonTimeout, "addon-timeout", 2*time.Minute, "how long to wait for each addon's ResourceTracker to be garbage-collected")
+	cmd.Flags().BoolVar(&unInstallArgs.DryRun, "dry-run", false, "print the uninstall plan without mutating the cluster")
+	cmd.Flags().StringVarP(&unInstallArgs.Output, "output", "o", "", "output format for --dry-run: yaml, json, or empty for a human-readable summary")
 	return cmd
 }
 
@@ -127,3 +247,352 @@ func deleteNamespace(kubeClient client.Client, namespace string) error {
 	ns.Name = namespace
 	return kubeClient.Delete(context.Background(), &ns)
 }
+
+// removeOAMCRDs enumerates the CRDs owned by KubeVela, strips finalizers from
+// any lingering custom resources so the CRDs can actually terminate, and then
+// deletes the CRDs themselves. It refuses to run if other custom resources
+// still exist for a targeted CRD unless force is set.
+func removeOAMCRDs(ctx context.Context, restConfig *rest.Config, force bool, ioStreams util.IOStreams) error {
+	extClient, err := apiextensionsclientset.NewForConfig(restConfig)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build apiextensions client")
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build dynamic client")
+	}
+
+	crdList, err := extClient.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list CRDs")
+	}
+
+	byGroup := map[string][]apiextensionsv1.CustomResourceDefinition{}
+	for _, crd := range crdList.Items {
+		if !isOAMGroup(crd.Spec.Group) {
+			continue
+		}
+		byGroup[crd.Spec.Group] = append(byGroup[crd.Spec.Group], crd)
+	}
+
+	groups := make([]string, 0, len(byGroup))
+	for group := range byGroup {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	if !force {
+		for _, group := range groups {
+			for _, crd := range byGroup[group] {
+				version := storedVersion(crd)
+				if version == "" {
+					continue
+				}
+				crList, err := dynamicClient.Resource(schemaGroupVersionResource(crd, version)).Namespace("").List(ctx, metav1.ListOptions{})
+				if err == nil && len(crList.Items) > 0 {
+					return fmt.Errorf("%d custom resource(s) of kind %s still exist, re-run with --force to strip finalizers and delete them", len(crList.Items), crd.Spec.Names.Kind)
+				}
+			}
+		}
+	}
+
+	for _, group := range groups {
+		crds := byGroup[group]
+		ioStreams.Info(fmt.Sprintf("Removing %d CRD(s) in group %s", len(crds), group))
+		for _, crd := range crds {
+			if err := removeOAMCRD(ctx, dynamicClient, extClient, crd, force, ioStreams); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func removeOAMCRD(ctx context.Context, dynamicClient dynamic.Interface, extClient apiextensionsclientset.Interface, crd apiextensionsv1.CustomResourceDefinition, force bool, ioStreams util.IOStreams) error {
+	version := storedVersion(crd)
+	if version == "" {
+		return fmt.Errorf("CRD %s has no storage version", crd.Name)
+	}
+	gvr := schemaGroupVersionResource(crd, version)
+	crList, err := dynamicClient.Resource(gvr).Namespace("").List(ctx, metav1.ListOptions{})
+	if err != nil && !apierror.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to list custom resources for %s", crd.Name)
+	}
+	if crList == nil {
+		crList = &unstructured.UnstructuredList{}
+	}
+	if len(crList.Items) > 0 && !force {
+		return fmt.Errorf("%d custom resource(s) of kind %s still exist, re-run with --force to strip finalizers and delete them", len(crList.Items), crd.Spec.Names.Kind)
+	}
+	for i := range crList.Items {
+		cr := &crList.Items[i]
+		if len(cr.GetFinalizers()) == 0 {
+			continue
+		}
+		cr.SetFinalizers(nil)
+		if _, err := dynamicClient.Resource(gvr).Namespace(cr.GetNamespace()).Update(ctx, cr, metav1.UpdateOptions{}); err != nil && !apierror.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to strip finalizers from %s/%s", cr.GetNamespace(), cr.GetName())
+		}
+	}
+	ioStreams.Info(fmt.Sprintf("Deleting CRD %s", crd.Name))
+	if err := extClient.ApiextensionsV1().CustomResourceDefinitions().Delete(ctx, crd.Name, metav1.DeleteOptions{}); err != nil && !apierror.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete CRD %s", crd.Name)
+	}
+	return nil
+}
+
+func isOAMGroup(group string) bool {
+	for _, g := range oamCRDGroups {
+		if group == g || strings.HasSuffix(group, "."+g) {
+			return true
+		}
+	}
+	return false
+}
+
+func storedVersion(crd apiextensionsv1.CustomResourceDefinition) string {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+func schemaGroupVersionResource(crd apiextensionsv1.CustomResourceDefinition, version string) schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    crd.Spec.Group,
+		Version:  version,
+		Resource: crd.Spec.Names.Plural,
+	}
+}
+
+// teardownAddons finds every Application created by `vela addon enable`
+// (identified by the addonNameLabel) and disables them oldest-last: the
+// Applications are sorted by CreationTimestamp and then walked back to
+// front, so an addon enabled after another (and thus more likely to depend
+// on it) is disabled first. This is only an approximation of true
+// dependency order, since KubeVela does not record addon dependency edges
+// on the Application itself. It waits up to timeout for each addon's
+// ResourceTracker to be garbage-collected before moving to the next.
+func teardownAddons(ctx context.Context, kubeClient client.Client, timeout time.Duration, ioStreams util.IOStreams) error {
+	var apps v1beta1.ApplicationList
+	if err := kubeClient.List(ctx, &apps, client.HasLabels{addonNameLabel}); err != nil {
+		return errors.Wrapf(err, "failed to list addon applications")
+	}
+	if len(apps.Items) == 0 {
+		return nil
+	}
+	sort.Slice(apps.Items, func(i, j int) bool {
+		return apps.Items[i].CreationTimestamp.Before(&apps.Items[j].CreationTimestamp)
+	})
+	ioStreams.Info(fmt.Sprintf("Found %d addon(s) to disable before uninstalling KubeVela", len(apps.Items)))
+	for i := len(apps.Items) - 1; i >= 0; i-- {
+		app := apps.Items[i]
+		name := app.Labels[addonNameLabel]
+		if err := disableAddon(ctx, kubeClient, app, name, timeout, ioStreams); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// disableAddon calls the same addon-disable path `vela addon disable
+// <name>` uses, then waits for the ResourceTracker the addon's Application
+// owned to disappear, which signals that all of the addon's managed
+// resources were reclaimed.
+func disableAddon(ctx context.Context, kubeClient client.Client, app v1beta1.Application, name string, timeout time.Duration, ioStreams util.IOStreams) error {
+	ioStreams.Info(fmt.Sprintf("Disabling addon %s", name))
+	if err := pkgaddon.DisableAddon(ctx, kubeClient, app); err != nil {
+		return errors.Wrapf(err, "failed to disable addon %s", name)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		var trackers v1beta1.ResourceTrackerList
+		err := kubeClient.List(ctx, &trackers, client.MatchingLabels{resourceTrackerAppLabel: app.Name})
+		if err != nil {
+			return errors.Wrapf(err, "failed to check resource trackers for addon %s", name)
+		}
+		if len(trackers.Items) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for addon %s's resource tracker to be garbage-collected", name)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// terminationError is returned by waitForTermination when --timeout elapses
+// while resources are still terminating, so callers such as CI pipelines can
+// inspect exactly what was left behind.
+type terminationError struct {
+	Remaining []string
+}
+
+func (e *terminationError) Error() string {
+	return fmt.Sprintf("timed out waiting for termination, resources still present: %s", strings.Join(e.Remaining, ", "))
+}
+
+// waitForTermination polls the vela-core deployments, any admission webhooks
+// owned by KubeVela, and the given namespaces until they are all gone or
+// timeout elapses, printing progress to ioStreams every pollInterval.
+func waitForTermination(ctx context.Context, kubeClient client.Client, controllerNamespace string, namespaces []string, timeout time.Duration, ioStreams util.IOStreams) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		remaining := remainingResources(ctx, kubeClient, controllerNamespace, namespaces)
+		if len(remaining) == 0 {
+			ioStreams.Info("All KubeVela resources have terminated")
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return &terminationError{Remaining: remaining}
+		}
+		ioStreams.Info(fmt.Sprintf("Waiting for termination, still present: %s", strings.Join(remaining, ", ")))
+		time.Sleep(pollInterval)
+	}
+}
+
+func remainingResources(ctx context.Context, kubeClient client.Client, controllerNamespace string, namespaces []string) []string {
+	var remaining []string
+
+	for _, name := range velaCoreDeployments {
+		var deploy appsv1.Deployment
+		if err := kubeClient.Get(ctx, apitypes.NamespacedName{Namespace: controllerNamespace, Name: name}, &deploy); err == nil {
+			remaining = append(remaining, "deployment/"+name)
+		}
+	}
+
+	var webhooks admissionregistrationv1.ValidatingWebhookConfigurationList
+	if err := kubeClient.List(ctx, &webhooks); err == nil {
+		for _, wh := range webhooks.Items {
+			if strings.Contains(wh.Name, "kubevela") || strings.Contains(wh.Name, "vela-core") {
+				remaining = append(remaining, "validatingwebhookconfiguration/"+wh.Name)
+			}
+		}
+	}
+	var mutatingWebhooks admissionregistrationv1.MutatingWebhookConfigurationList
+	if err := kubeClient.List(ctx, &mutatingWebhooks); err == nil {
+		for _, wh := range mutatingWebhooks.Items {
+			if strings.Contains(wh.Name, "kubevela") || strings.Contains(wh.Name, "vela-core") {
+				remaining = append(remaining, "mutatingwebhookconfiguration/"+wh.Name)
+			}
+		}
+	}
+
+	for _, ns := range namespaces {
+		var namespace corev1.Namespace
+		if err := kubeClient.Get(ctx, apitypes.NamespacedName{Name: ns}, &namespace); err == nil {
+			remaining = append(remaining, "namespace/"+ns)
+		}
+	}
+
+	return remaining
+}
+
+// buildUninstallPlan runs the same pre-flight discovery the real uninstall
+// would (Applications, addons, the kubevela namespace, and optionally CRDs)
+// without deleting or mutating anything in the cluster.
+func buildUninstallPlan(ctx context.Context, kubeClient client.Client, args *UnInstallArgs) (*uninstallPlan, error) {
+	plan := &uninstallPlan{
+		Release:                kubeVelaReleaseName,
+		Namespace:              args.Namespace,
+		NamespaceWillBeDeleted: !args.PreserveNamespace,
+	}
+
+	var apps v1beta1.ApplicationList
+	if err := kubeClient.List(ctx, &apps, &client.ListOptions{Namespace: ""}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list applications")
+	}
+	for _, app := range apps.Items {
+		if name, isAddon := app.Labels[addonNameLabel]; isAddon {
+			plan.Addons = append(plan.Addons, name)
+		} else {
+			plan.Applications++
+		}
+	}
+
+	var namespace corev1.Namespace
+	if err := kubeClient.Get(ctx, apitypes.NamespacedName{Name: "kubevela"}, &namespace); err == nil {
+		plan.KubevelaNamespaceExists = true
+	} else if !apierror.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "failed to check if namespace kubevela already exists")
+	}
+
+	if args.RemoveCRDs {
+		restConfig, err := args.Args.GetConfig()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get kube config")
+		}
+		extClient, err := apiextensionsclientset.NewForConfig(restConfig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build apiextensions client")
+		}
+		dynamicClient, err := dynamic.NewForConfig(restConfig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build dynamic client")
+		}
+		crdList, err := extClient.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list CRDs")
+		}
+		plan.CustomResourceCounts = map[string]int{}
+		for _, crd := range crdList.Items {
+			if !isOAMGroup(crd.Spec.Group) {
+				continue
+			}
+			plan.CRDsToRemove = append(plan.CRDsToRemove, crd.Name)
+			version := storedVersion(crd)
+			if version == "" {
+				continue
+			}
+			crList, err := dynamicClient.Resource(schemaGroupVersionResource(crd, version)).Namespace("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				continue
+			}
+			plan.CustomResourceCounts[crd.Spec.Names.Kind] = len(crList.Items)
+		}
+	}
+
+	return plan, nil
+}
+
+// printUninstallPlan renders the plan as YAML or JSON when format is one of
+// those two values, as a short human-readable summary when format is empty,
+// and returns an error for any other format.
+func printUninstallPlan(plan *uninstallPlan, format string, ioStreams util.IOStreams) error {
+	switch format {
+	case "":
+		ioStreams.Info(fmt.Sprintf("Would uninstall Helm release %q\n"+
+			"Namespace %q would be: %s\n"+
+			"Namespace \"kubevela\" exists: %t\n"+
+			"Applications to be blocked on: %d\n"+
+			"Addons to be disabled: %d\n"+
+			"CRDs to be removed: %d",
+			plan.Release, plan.Namespace, namespaceAction(plan.NamespaceWillBeDeleted),
+			plan.KubevelaNamespaceExists, plan.Applications, len(plan.Addons), len(plan.CRDsToRemove)))
+	case "json":
+		out, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal uninstall plan as json")
+		}
+		ioStreams.Info(string(out))
+	case "yaml":
+		out, err := yaml.Marshal(plan)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal uninstall plan as yaml")
+		}
+		ioStreams.Info(string(out))
+	default:
+		return fmt.Errorf("unsupported output format %q, must be yaml or json", format)
+	}
+	return nil
+}
+
+func namespaceAction(willDelete bool) string {
+	if willDelete {
+		return "deleted"
+	}
+	return "preserved"
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/utils/util"
+)
+
+// discardIOStreams is a util.IOStreams safe to pass into code paths that
+// call Info/Error, without printing to the test's real stdout/stderr.
+func discardIOStreams() util.IOStreams {
+	return util.IOStreams{Out: io.Discard, ErrOut: io.Discard}
+}
+
+// deleteOrderRecorder wraps a client.Client and records the name of every
+// Application passed to Delete, in call order.
+type deleteOrderRecorder struct {
+	client.Client
+	deleted *[]string
+}
+
+func (r *deleteOrderRecorder) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if app, ok := obj.(*v1beta1.Application); ok {
+		*r.deleted = append(*r.deleted, app.Name)
+	}
+	return r.Client.Delete(ctx, obj, opts...)
+}
+
+func addonApp(name string, createdAt time.Time) *v1beta1.Application {
+	return &v1beta1.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "vela-system",
+			Labels:            map[string]string{addonNameLabel: name},
+			CreationTimestamp: metav1.NewTime(createdAt),
+		},
+	}
+}
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestTeardownAddons_DisablesNewestFirst(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := addonApp("fluxcd", base)
+	second := addonApp("velaux", base.Add(time.Minute))
+	third := addonApp("terraform", base.Add(2*time.Minute))
+
+	scheme := newTestScheme(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(first, second, third).Build()
+	var deleted []string
+	recorder := &deleteOrderRecorder{Client: fakeClient, deleted: &deleted}
+
+	err := teardownAddons(context.Background(), recorder, time.Second, discardIOStreams())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"terraform", "velaux", "fluxcd"}, deleted)
+}
+
+func TestTeardownAddons_NoAddonsIsNoop(t *testing.T) {
+	scheme := newTestScheme(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	err := teardownAddons(context.Background(), fakeClient, time.Second, discardIOStreams())
+	require.NoError(t, err)
+}
+
+func TestTeardownAddons_TimesOutWhenResourceTrackerLingers(t *testing.T) {
+	app := addonApp("fluxcd", time.Now())
+	tracker := &v1beta1.ResourceTracker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "app-vela-system-fluxcd",
+			Labels: map[string]string{resourceTrackerAppLabel: app.Name},
+		},
+	}
+
+	scheme := newTestScheme(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, tracker).Build()
+
+	err := teardownAddons(context.Background(), fakeClient, 0, discardIOStreams())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out waiting")
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func testCRD() apiextensionsv1.CustomResourceDefinition {
+	return apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "components.core.oam.dev"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "core.oam.dev",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural: "components",
+				Kind:   "Component",
+			},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1beta1", Storage: true},
+			},
+		},
+	}
+}
+
+func testComponentCR() *unstructured.Unstructured {
+	cr := &unstructured.Unstructured{}
+	cr.SetAPIVersion("core.oam.dev/v1beta1")
+	cr.SetKind("Component")
+	cr.SetName("my-component")
+	cr.SetNamespace("default")
+	cr.SetFinalizers([]string{"component.core.oam.dev/finalizer"})
+	return cr
+}
+
+func TestRemoveOAMCRD_NoForceBlocksOnExistingCR(t *testing.T) {
+	crd := testCRD()
+	gvr := schema.GroupVersionResource{Group: "core.oam.dev", Version: "v1beta1", Resource: "components"}
+	scheme := runtime.NewScheme()
+	listKinds := map[schema.GroupVersionResource]string{gvr: "ComponentList"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds, testComponentCR())
+	extClient := apiextensionsfake.NewSimpleClientset(&crd)
+
+	err := removeOAMCRD(context.Background(), dynamicClient, extClient, crd, false, discardIOStreams())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+
+	_, getErr := extClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.Background(), crd.Name, metav1.GetOptions{})
+	assert.NoError(t, getErr, "CRD should not have been deleted when blocked")
+}
+
+func TestRemoveOAMCRD_ForceStripsFinalizersAndDeletes(t *testing.T) {
+	crd := testCRD()
+	gvr := schema.GroupVersionResource{Group: "core.oam.dev", Version: "v1beta1", Resource: "components"}
+	scheme := runtime.NewScheme()
+	listKinds := map[schema.GroupVersionResource]string{gvr: "ComponentList"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds, testComponentCR())
+	extClient := apiextensionsfake.NewSimpleClientset(&crd)
+
+	err := removeOAMCRD(context.Background(), dynamicClient, extClient, crd, true, discardIOStreams())
+	require.NoError(t, err)
+
+	cr, err := dynamicClient.Resource(gvr).Namespace("default").Get(context.Background(), "my-component", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, cr.GetFinalizers(), "finalizers should have been stripped")
+
+	_, err = extClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.Background(), crd.Name, metav1.GetOptions{})
+	assert.Error(t, err, "CRD should have been deleted")
+}
+
+func TestRemoveOAMCRD_NoServedVersionErrors(t *testing.T) {
+	crd := testCRD()
+	crd.Spec.Versions = nil
+	scheme := runtime.NewScheme()
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme)
+	extClient := apiextensionsfake.NewSimpleClientset(&crd)
+
+	err := removeOAMCRD(context.Background(), dynamicClient, extClient, crd, true, discardIOStreams())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no storage version")
+}
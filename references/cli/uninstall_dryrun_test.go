@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+func TestBuildUninstallPlan_CountsAppsAndAddonsSeparately(t *testing.T) {
+	scheme := newTestScheme(t)
+	userApp := &v1beta1.Application{ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: "default"}}
+	addon := addonApp("fluxcd", metav1.Now().Time)
+	kubevelaNS := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kubevela"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(userApp, addon, kubevelaNS).Build()
+
+	plan, err := buildUninstallPlan(context.Background(), fakeClient, &UnInstallArgs{Namespace: "vela-system"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, plan.Applications)
+	assert.Equal(t, []string{"fluxcd"}, plan.Addons)
+	assert.True(t, plan.KubevelaNamespaceExists)
+	assert.True(t, plan.NamespaceWillBeDeleted)
+	assert.Nil(t, plan.CRDsToRemove, "CRDsToRemove should stay empty when --remove-crds isn't set")
+}
+
+func TestBuildUninstallPlan_PreservesNamespaceIsReflected(t *testing.T) {
+	scheme := newTestScheme(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	plan, err := buildUninstallPlan(context.Background(), fakeClient, &UnInstallArgs{Namespace: "vela-system", PreserveNamespace: true})
+	require.NoError(t, err)
+
+	assert.False(t, plan.NamespaceWillBeDeleted)
+	assert.False(t, plan.KubevelaNamespaceExists)
+}
+
+func TestPrintUninstallPlan_RejectsUnknownFormat(t *testing.T) {
+	err := printUninstallPlan(&uninstallPlan{}, "toml", discardIOStreams())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported output format")
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWaitForTermination_ReturnsOnceResourcesAreGone(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+
+	err := waitForTermination(context.Background(), fakeClient, "vela-system", []string{"vela-system"}, time.Second, discardIOStreams())
+	require.NoError(t, err)
+}
+
+func TestWaitForTermination_UsesConfiguredNamespaceForDeployments(t *testing.T) {
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: velaCoreDeployments[0], Namespace: "custom-ns"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(deploy).Build()
+
+	// A stale hardcoded "vela-system" check would miss this deployment and
+	// falsely report termination; remainingResources must be told the real
+	// controller namespace.
+	remaining := remainingResources(context.Background(), fakeClient, "custom-ns", nil)
+	assert.Contains(t, remaining, "deployment/"+velaCoreDeployments[0])
+
+	remaining = remainingResources(context.Background(), fakeClient, "vela-system", nil)
+	assert.NotContains(t, remaining, "deployment/"+velaCoreDeployments[0])
+}
+
+func TestWaitForTermination_TimesOutWithRemainingNamespace(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "vela-system"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(ns).Build()
+
+	err := waitForTermination(context.Background(), fakeClient, "vela-system", []string{"vela-system"}, 0, discardIOStreams())
+	require.Error(t, err)
+	var termErr *terminationError
+	require.ErrorAs(t, err, &termErr)
+	assert.Contains(t, termErr.Remaining, "namespace/vela-system")
+}